@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"jv/internal/jsondiff"
+)
+
+var (
+	diffAddedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A"))
+	diffRemovedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#F7768E"))
+	diffModifiedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E0AF68"))
+	diffEmptyStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#414868"))
+)
+
+// runDiffMode implements `jv diff a.json b.json`: it parses both files and
+// drives a two-pane DiffModel instead of the regular single-tree Model.
+func runDiffMode(args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: jv diff <a.json> <b.json>")
+		os.Exit(1)
+	}
+
+	aData, err := loadDiffSide(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	bData, err := loadDiffSide(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[1], err)
+		os.Exit(1)
+	}
+
+	m := NewDiffModel(args[0], args[1], aData, bData)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func loadDiffSide(path string) (interface{}, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := parseInput(string(raw), "")
+	return data, err
+}
+
+// DiffModel drives the two-pane diff view produced by `jv diff`.
+type DiffModel struct {
+	labelA, labelB string
+	root           *jsondiff.DiffNode
+	flat           []*jsondiff.DiffNode
+	flatDepth      []int // depth of flat[i], parallel slice (DiffNode has no parent pointer)
+	cursor         int
+	width, height  int
+
+	leftViewport, rightViewport viewport.Model
+	ready                       bool
+}
+
+// NewDiffModel builds the DiffNode tree for a vs. b and flattens it for
+// navigation.
+func NewDiffModel(labelA, labelB string, a, b interface{}) DiffModel {
+	m := DiffModel{
+		labelA: labelA,
+		labelB: labelB,
+		root:   jsondiff.Diff(a, b),
+	}
+	m.flatten()
+	return m
+}
+
+func (m *DiffModel) flatten() {
+	m.flat = nil
+	m.flatDepth = nil
+	var walk func(*jsondiff.DiffNode, int)
+	walk = func(node *jsondiff.DiffNode, depth int) {
+		m.flat = append(m.flat, node)
+		m.flatDepth = append(m.flatDepth, depth)
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, child := range m.root.Children {
+		walk(child, 0)
+	}
+}
+
+func (m DiffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		headerHeight := 1
+		footerHeight := 1
+		contentHeight := m.height - headerHeight - footerHeight
+		pw := m.paneWidth()
+
+		if !m.ready {
+			m.leftViewport = viewport.New(pw, contentHeight)
+			m.rightViewport = viewport.New(pw, contentHeight)
+			m.ready = true
+		} else {
+			m.leftViewport.Width = pw
+			m.rightViewport.Width = pw
+			m.leftViewport.Height = contentHeight
+			m.rightViewport.Height = contentHeight
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+				m.ensureCursorVisible()
+			}
+		case "down", "j":
+			if m.cursor < len(m.flat)-1 {
+				m.cursor++
+				m.ensureCursorVisible()
+			}
+		case "n":
+			m.jumpToChange(1)
+		case "N":
+			m.jumpToChange(-1)
+		case "g":
+			m.cursor = 0
+			m.ensureCursorVisible()
+		case "G":
+			m.cursor = len(m.flat) - 1
+			m.ensureCursorVisible()
+		}
+	}
+	return m, nil
+}
+
+// paneWidth is the rendered width of each side of the split, derived from
+// the terminal width the same way View lays out the two borders.
+func (m DiffModel) paneWidth() int {
+	pw := (m.width - 6) / 2
+	if pw < 10 {
+		pw = 10
+	}
+	return pw
+}
+
+// ensureCursorVisible scrolls both panes (kept in lockstep so aligned rows
+// stay aligned) so the cursor row stays on screen, mirroring Model's
+// ensureCursorVisible.
+func (m *DiffModel) ensureCursorVisible() {
+	if m.cursor < m.leftViewport.YOffset {
+		m.leftViewport.YOffset = m.cursor
+		m.rightViewport.YOffset = m.cursor
+	} else if m.cursor >= m.leftViewport.YOffset+m.leftViewport.Height {
+		offset := m.cursor - m.leftViewport.Height + 1
+		m.leftViewport.YOffset = offset
+		m.rightViewport.YOffset = offset
+	}
+}
+
+// jumpToChange moves the cursor to the next (dir=1) or previous (dir=-1)
+// node whose Change is not Unchanged, wrapping around.
+func (m *DiffModel) jumpToChange(dir int) {
+	if len(m.flat) == 0 {
+		return
+	}
+	i := m.cursor
+	for range m.flat {
+		i = (i + dir + len(m.flat)) % len(m.flat)
+		if m.flat[i].Change != jsondiff.Unchanged {
+			m.cursor = i
+			m.ensureCursorVisible()
+			return
+		}
+	}
+}
+
+func (m DiffModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	title := titleStyle.Render(fmt.Sprintf("jv diff — %s vs %s", m.labelA, m.labelB))
+	help := helpStyle.Render("↑↓/jk: nav • n/N: next/prev change • q: quit")
+
+	paneWidth := m.paneWidth()
+
+	var left, right strings.Builder
+	for i, node := range m.flat {
+		oldLine, newLine := renderDiffPane(node, m.flatDepth[i])
+		if i == m.cursor {
+			oldLine = cursorStyle.Render(padTo(oldLine, paneWidth))
+			newLine = cursorStyle.Render(padTo(newLine, paneWidth))
+		}
+		left.WriteString(oldLine)
+		right.WriteString(newLine)
+		if i < len(m.flat)-1 {
+			left.WriteString("\n")
+			right.WriteString("\n")
+		}
+	}
+
+	m.leftViewport.SetContent(left.String())
+	m.rightViewport.SetContent(right.String())
+
+	leftPane := borderStyle.Width(paneWidth).Render(m.leftViewport.View())
+	rightPane := borderStyle.Width(paneWidth).Render(m.rightViewport.View())
+	body := lipgloss.JoinHorizontal(lipgloss.Top, leftPane, rightPane)
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, body, help)
+}
+
+// renderDiffPane renders node's old-side and new-side lines, colored by
+// Change: green for additions, red for removals, yellow for modified
+// scalars, and dimmed placeholders on the side a node doesn't exist on.
+func renderDiffPane(node *jsondiff.DiffNode, depth int) (string, string) {
+	indent := strings.Repeat("  ", depth)
+	label := diffNodeLabel(node)
+
+	switch node.Change {
+	case jsondiff.Added:
+		return diffEmptyStyle.Render(indent + "·"), diffAddedStyle.Render(indent + label + " " + diffValueText(node.New))
+	case jsondiff.Removed:
+		return diffRemovedStyle.Render(indent + label + " " + diffValueText(node.Old)), diffEmptyStyle.Render(indent + "·")
+	case jsondiff.Modified:
+		if isContainer(node.Old) || isContainer(node.New) {
+			return diffModifiedStyle.Render(indent + label), diffModifiedStyle.Render(indent + label)
+		}
+		return diffModifiedStyle.Render(indent + label + " " + diffValueText(node.Old)),
+			diffModifiedStyle.Render(indent + label + " " + diffValueText(node.New))
+	default:
+		return indent + label + " " + diffValueText(node.Old), indent + label + " " + diffValueText(node.New)
+	}
+}
+
+func diffNodeLabel(node *jsondiff.DiffNode) string {
+	if node.Key != "" {
+		return fmt.Sprintf(`"%s":`, node.Key)
+	}
+	if node.Index >= 0 {
+		return fmt.Sprintf("[%d]", node.Index)
+	}
+	return ""
+}
+
+func diffValueText(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return ""
+	case nil:
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func isContainer(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+func padTo(s string, width int) string {
+	visible := lipgloss.Width(s)
+	if visible < width {
+		return s + strings.Repeat(" ", width-visible)
+	}
+	return s
+}
@@ -0,0 +1,235 @@
+// Package jsondiff recursively diffs two generic interface{} trees
+// produced by encoding/json, producing a DiffNode tree that drives
+// side-by-side rendering in the viewer's diff mode.
+package jsondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Change describes how a node differs between the old and new documents.
+type Change int
+
+const (
+	Unchanged Change = iota
+	Added
+	Removed
+	Modified
+)
+
+func (c Change) String() string {
+	switch c {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return "unchanged"
+	}
+}
+
+// DiffNode is one node of the diffed tree. Key is set for object
+// children, Index for array children (Key is "" and Index is -1 at the
+// root; object children also have Index -1).
+// Old/New hold the corresponding raw values, whichever side(s) the node
+// exists on.
+type DiffNode struct {
+	Key      string
+	Index    int
+	Change   Change
+	Old      interface{}
+	New      interface{}
+	Children []*DiffNode
+}
+
+// Diff compares a (old) against b (new) and returns the root of the
+// resulting DiffNode tree.
+func Diff(a, b interface{}) *DiffNode {
+	return diffValue("", -1, a, b)
+}
+
+func diffValue(key string, index int, a, b interface{}) *DiffNode {
+	node := &DiffNode{Key: key, Index: index, Old: a, New: b}
+
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		node.Children = diffObjects(aMap, bMap)
+		node.Change = aggregateChange(node.Children, a == nil, b == nil)
+		return node
+	}
+
+	aArr, aIsArr := a.([]interface{})
+	bArr, bIsArr := b.([]interface{})
+	if aIsArr && bIsArr {
+		node.Children = diffArrays(aArr, bArr)
+		node.Change = aggregateChange(node.Children, a == nil, b == nil)
+		return node
+	}
+
+	switch {
+	case a == nil && b != nil:
+		node.Change = Added
+	case a != nil && b == nil:
+		node.Change = Removed
+	case !equalScalar(a, b):
+		node.Change = Modified
+	default:
+		node.Change = Unchanged
+	}
+	return node
+}
+
+// aggregateChange rolls a container's own Change up from its children:
+// Added/Removed if the whole container is new/gone, otherwise Modified
+// if any child changed, else Unchanged.
+func aggregateChange(children []*DiffNode, wasNil, isNil bool) Change {
+	if wasNil && !isNil {
+		return Added
+	}
+	if !wasNil && isNil {
+		return Removed
+	}
+	for _, child := range children {
+		if child.Change != Unchanged {
+			return Modified
+		}
+	}
+	return Unchanged
+}
+
+func diffObjects(a, b map[string]interface{}) []*DiffNode {
+	var keys []string
+	seen := map[string]bool{}
+	for k := range a {
+		keys = append(keys, k)
+		seen[k] = true
+	}
+	for k := range b {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	children := make([]*DiffNode, 0, len(keys))
+	for _, k := range keys {
+		av, aok := a[k]
+		bv, bok := b[k]
+		switch {
+		case aok && !bok:
+			children = append(children, diffValue(k, -1, av, nil))
+		case !aok && bok:
+			children = append(children, diffValue(k, -1, nil, bv))
+		default:
+			children = append(children, diffValue(k, -1, av, bv))
+		}
+	}
+	return children
+}
+
+// diffArrays aligns a and b with an LCS over element hashes (rather than
+// comparing pairwise by index) so an insertion or reorder doesn't cascade
+// into every following element looking "modified".
+func diffArrays(a, b []interface{}) []*DiffNode {
+	aHashes := hashAll(a)
+	bHashes := hashAll(b)
+	ops := lcsAlign(aHashes, bHashes)
+
+	children := make([]*DiffNode, 0, len(ops))
+	index := 0
+	for _, op := range ops {
+		switch op.kind {
+		case opMatch:
+			children = append(children, diffValue("", index, a[op.aIdx], b[op.bIdx]))
+			index++
+		case opRemove:
+			children = append(children, diffValue("", index, a[op.aIdx], nil))
+			index++
+		case opInsert:
+			children = append(children, diffValue("", index, nil, b[op.bIdx]))
+			index++
+		}
+	}
+	return children
+}
+
+func hashAll(items []interface{}) []string {
+	hashes := make([]string, len(items))
+	for i, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			hashes[i] = fmt.Sprintf("%v", item)
+			continue
+		}
+		hashes[i] = string(b)
+	}
+	return hashes
+}
+
+type opKind int
+
+const (
+	opMatch opKind = iota
+	opRemove
+	opInsert
+)
+
+type alignOp struct {
+	kind       opKind
+	aIdx, bIdx int
+}
+
+// lcsAlign computes the longest common subsequence of a and b (by value
+// equality of their hashes) and walks it to emit a match/remove/insert
+// sequence that reconstructs b from a.
+func lcsAlign(a, b []string) []alignOp {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var ops []alignOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, alignOp{kind: opMatch, aIdx: i, bIdx: j})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			ops = append(ops, alignOp{kind: opRemove, aIdx: i})
+			i++
+		default:
+			ops = append(ops, alignOp{kind: opInsert, bIdx: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, alignOp{kind: opRemove, aIdx: i})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, alignOp{kind: opInsert, bIdx: j})
+	}
+	return ops
+}
+
+func equalScalar(a, b interface{}) bool {
+	return fmt.Sprintf("%T:%v", a, a) == fmt.Sprintf("%T:%v", b, b)
+}
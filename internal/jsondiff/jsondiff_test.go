@@ -0,0 +1,175 @@
+package jsondiff
+
+import "testing"
+
+// childByIndex finds node's child at array index idx.
+func childByIndex(node *DiffNode, idx int) *DiffNode {
+	for _, c := range node.Children {
+		if c.Index == idx {
+			return c
+		}
+	}
+	return nil
+}
+
+// childByKey finds node's child with object key key.
+func childByKey(node *DiffNode, key string) *DiffNode {
+	for _, c := range node.Children {
+		if c.Key == key {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestDiffScalars(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		want Change
+	}{
+		{name: "unchanged string", a: "x", b: "x", want: Unchanged},
+		{name: "modified string", a: "x", b: "y", want: Modified},
+		{name: "unchanged number", a: 1.0, b: 1.0, want: Unchanged},
+		{name: "modified number", a: 1.0, b: 2.0, want: Modified},
+		{name: "added", a: nil, b: "x", want: Added},
+		{name: "removed", a: "x", b: nil, want: Removed},
+		{name: "both nil", a: nil, b: nil, want: Unchanged},
+		{name: "type change counts as modified", a: "1", b: 1.0, want: Modified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := Diff(tt.a, tt.b)
+			if node.Change != tt.want {
+				t.Errorf("Diff(%v, %v).Change = %v, want %v", tt.a, tt.b, node.Change, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffObjects(t *testing.T) {
+	a := map[string]interface{}{"keep": "x", "remove": "y", "change": 1.0}
+	b := map[string]interface{}{"keep": "x", "add": "z", "change": 2.0}
+
+	node := Diff(a, b)
+	if node.Change != Modified {
+		t.Fatalf("root Change = %v, want Modified", node.Change)
+	}
+
+	if c := childByKey(node, "keep"); c == nil || c.Change != Unchanged {
+		t.Errorf("keep: got %+v, want Unchanged", c)
+	}
+	if c := childByKey(node, "remove"); c == nil || c.Change != Removed {
+		t.Errorf("remove: got %+v, want Removed", c)
+	}
+	if c := childByKey(node, "add"); c == nil || c.Change != Added {
+		t.Errorf("add: got %+v, want Added", c)
+	}
+	if c := childByKey(node, "change"); c == nil || c.Change != Modified {
+		t.Errorf("change: got %+v, want Modified", c)
+	}
+}
+
+func TestDiffArraysInsertions(t *testing.T) {
+	// b inserts "x" at the front and appends "new"; "a", "b", "c" keep
+	// their relative order. A naive pairwise-by-index diff would mark
+	// nearly everything "modified" because of the shift; the LCS aligner
+	// should instead report one insert, the shared elements as unchanged,
+	// and one more insert at the end.
+	a := []interface{}{"a", "b", "c"}
+	b := []interface{}{"x", "a", "b", "c", "new"}
+
+	node := Diff(a, b)
+	if node.Change != Modified {
+		t.Fatalf("root Change = %v, want Modified", node.Change)
+	}
+	if len(node.Children) != 5 {
+		t.Fatalf("len(Children) = %d, want 5: %+v", len(node.Children), node.Children)
+	}
+
+	wantChanges := []Change{Added, Unchanged, Unchanged, Unchanged, Added}
+	for i, want := range wantChanges {
+		if got := node.Children[i].Change; got != want {
+			t.Errorf("Children[%d].Change = %v, want %v", i, got, want)
+		}
+	}
+	if node.Children[1].Old != "a" || node.Children[1].New != "a" {
+		t.Errorf("Children[1] = %+v, want aligned on %q", node.Children[1], "a")
+	}
+}
+
+func TestDiffArraysRemoval(t *testing.T) {
+	a := []interface{}{"a", "b", "c"}
+	b := []interface{}{"a", "c"}
+
+	node := Diff(a, b)
+	removed := childByIndex(node, 1)
+	if removed == nil || removed.Change != Removed || removed.Old != "b" {
+		t.Fatalf("expected \"b\" removed at index 1, got %+v", removed)
+	}
+}
+
+func TestDiffNestedContainers(t *testing.T) {
+	// A changed object element inside an array hashes differently from its
+	// old value, so the LCS aligner can't line it up as "the same element,
+	// modified" — it sees a removal of the old element plus an insertion
+	// of the new one. That's the documented alignment behavior, not an
+	// in-place diff of the element's own fields.
+	a := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "one"},
+		},
+	}
+	b := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "uno"},
+		},
+	}
+
+	node := Diff(a, b)
+	if node.Change != Modified {
+		t.Fatalf("root Change = %v, want Modified", node.Change)
+	}
+	items := childByKey(node, "items")
+	if items == nil || items.Change != Modified {
+		t.Fatalf("items: got %+v, want Modified", items)
+	}
+	if len(items.Children) != 2 {
+		t.Fatalf("len(items.Children) = %d, want 2 (remove old + insert new): %+v", len(items.Children), items.Children)
+	}
+	if items.Children[0].Change != Removed {
+		t.Errorf("items.Children[0].Change = %v, want Removed", items.Children[0].Change)
+	}
+	if items.Children[1].Change != Added {
+		t.Errorf("items.Children[1].Change = %v, want Added", items.Children[1].Change)
+	}
+}
+
+func TestDiffNestedContainersUnchangedElement(t *testing.T) {
+	// When an array element's fields are untouched, diffObjects still
+	// recurses per-key so unrelated sibling fields resolve independently.
+	a := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "one"},
+		},
+	}
+	b := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "one"},
+		},
+	}
+
+	node := Diff(a, b)
+	if node.Change != Unchanged {
+		t.Fatalf("root Change = %v, want Unchanged", node.Change)
+	}
+	items := childByKey(node, "items")
+	if items == nil || items.Change != Unchanged {
+		t.Fatalf("items: got %+v, want Unchanged", items)
+	}
+	elem := childByIndex(items, 0)
+	if elem == nil || elem.Change != Unchanged {
+		t.Fatalf("items[0]: got %+v, want Unchanged", elem)
+	}
+}
@@ -0,0 +1,441 @@
+// Package jsonpath implements a small JSONPath / jq-style query evaluator
+// over generic interface{} trees produced by encoding/json.
+//
+// It supports the subset of JSONPath syntax most viewers need:
+//
+//	$                         root
+//	.key or ["key"]           child access
+//	[n]                       array index
+//	[start:end:step]          array slice
+//	..key                     recursive descent for key
+//	*                         wildcard (all children)
+//	[?(@.field op value)]     filter predicate (==, !=, <, <=, >, >=, =~)
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Match is a single node reached by evaluating an expression, along with
+// the concrete path that reached it (e.g. `$.users[2].name`).
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+// segment is one step of a compiled path.
+type segment struct {
+	kind string // "key", "recursive", "index", "slice", "wildcard", "filter"
+
+	key string // for "key" and "recursive"
+
+	index int // for "index"
+
+	start, end, step int  // for "slice"
+	hasStart, hasEnd bool // whether start/end were explicit
+
+	filter string // raw predicate text for "filter"
+}
+
+// Eval parses expr and evaluates it against root, returning every matching
+// node: array elements in index order, object members in sorted key order.
+func Eval(root interface{}, expr string) ([]Match, error) {
+	segments, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []Match{{Path: "$", Value: root}}
+	for _, seg := range segments {
+		var next []Match
+		for _, m := range matches {
+			results, err := applySegment(seg, m)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, results...)
+		}
+		matches = next
+	}
+	return matches, nil
+}
+
+func parse(expr string) ([]segment, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("jsonpath: expression must start with $: %q", expr)
+	}
+	rest := expr[1:]
+
+	var segments []segment
+	for len(rest) > 0 {
+		switch {
+		case strings.HasPrefix(rest, "..."):
+			return nil, fmt.Errorf("jsonpath: unexpected '...' in %q", expr)
+
+		case strings.HasPrefix(rest, ".."):
+			rest = rest[2:]
+			key, remainder, err := readKeyOrWildcard(rest)
+			if err != nil {
+				return nil, err
+			}
+			if key == "*" {
+				segments = append(segments, segment{kind: "recursive", key: "*"})
+			} else {
+				segments = append(segments, segment{kind: "recursive", key: key})
+			}
+			rest = remainder
+
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			key, remainder, err := readKeyOrWildcard(rest)
+			if err != nil {
+				return nil, err
+			}
+			if key == "*" {
+				segments = append(segments, segment{kind: "wildcard"})
+			} else {
+				segments = append(segments, segment{kind: "key", key: key})
+			}
+			rest = remainder
+
+		case strings.HasPrefix(rest, "["):
+			seg, remainder, err := readBracket(rest)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			rest = remainder
+
+		default:
+			return nil, fmt.Errorf("jsonpath: unexpected character at %q", rest)
+		}
+	}
+	return segments, nil
+}
+
+func readKeyOrWildcard(rest string) (string, string, error) {
+	if strings.HasPrefix(rest, "*") {
+		return "*", rest[1:], nil
+	}
+	i := 0
+	for i < len(rest) && rest[i] != '.' && rest[i] != '[' {
+		i++
+	}
+	if i == 0 {
+		return "", "", fmt.Errorf("jsonpath: expected key, got %q", rest)
+	}
+	return rest[:i], rest[i:], nil
+}
+
+func readBracket(rest string) (segment, string, error) {
+	end := strings.IndexByte(rest, ']')
+	if end == -1 {
+		return segment{}, "", fmt.Errorf("jsonpath: unterminated '[' in %q", rest)
+	}
+	inner := rest[1:end]
+	remainder := rest[end+1:]
+
+	switch {
+	case strings.HasPrefix(inner, "?("):
+		if !strings.HasSuffix(inner, ")") {
+			return segment{}, "", fmt.Errorf("jsonpath: malformed filter %q", inner)
+		}
+		return segment{kind: "filter", filter: strings.TrimSuffix(strings.TrimPrefix(inner, "?("), ")")}, remainder, nil
+
+	case inner == "*":
+		return segment{kind: "wildcard"}, remainder, nil
+
+	case strings.HasPrefix(inner, "\"") || strings.HasPrefix(inner, "'"):
+		key := strings.Trim(inner, `"'`)
+		return segment{kind: "key", key: key}, remainder, nil
+
+	case strings.Contains(inner, ":"):
+		return parseSlice(inner), remainder, nil
+
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return segment{}, "", fmt.Errorf("jsonpath: bad index %q", inner)
+		}
+		return segment{kind: "index", index: n}, remainder, nil
+	}
+}
+
+func parseSlice(inner string) segment {
+	parts := strings.Split(inner, ":")
+	seg := segment{kind: "slice", step: 1}
+	if len(parts) > 0 && parts[0] != "" {
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			seg.start, seg.hasStart = n, true
+		}
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if n, err := strconv.Atoi(parts[1]); err == nil {
+			seg.end, seg.hasEnd = n, true
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if n, err := strconv.Atoi(parts[2]); err == nil {
+			seg.step = n
+		}
+	}
+	return seg
+}
+
+func applySegment(seg segment, m Match) ([]Match, error) {
+	switch seg.kind {
+	case "key":
+		obj, ok := m.Value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		v, ok := obj[seg.key]
+		if !ok {
+			return nil, nil
+		}
+		return []Match{{Path: m.Path + "." + seg.key, Value: v}}, nil
+
+	case "wildcard":
+		switch v := m.Value.(type) {
+		case map[string]interface{}:
+			var out []Match
+			for _, k := range sortedKeys(v) {
+				out = append(out, Match{Path: m.Path + "." + k, Value: v[k]})
+			}
+			return out, nil
+		case []interface{}:
+			var out []Match
+			for i, item := range v {
+				out = append(out, Match{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: item})
+			}
+			return out, nil
+		}
+		return nil, nil
+
+	case "recursive":
+		var out []Match
+		var walk func(Match)
+		walk = func(cur Match) {
+			if seg.key == "*" {
+				out = append(out, cur)
+			}
+			switch v := cur.Value.(type) {
+			case map[string]interface{}:
+				for _, k := range sortedKeys(v) {
+					child := Match{Path: cur.Path + "." + k, Value: v[k]}
+					if seg.key != "*" && k == seg.key {
+						out = append(out, child)
+					}
+					walk(child)
+				}
+			case []interface{}:
+				for i, item := range v {
+					child := Match{Path: fmt.Sprintf("%s[%d]", cur.Path, i), Value: item}
+					walk(child)
+				}
+			}
+		}
+		walk(m)
+		return out, nil
+
+	case "index":
+		arr, ok := m.Value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		idx := seg.index
+		if idx < 0 {
+			idx += len(arr)
+		}
+		if idx < 0 || idx >= len(arr) {
+			return nil, nil
+		}
+		return []Match{{Path: fmt.Sprintf("%s[%d]", m.Path, idx), Value: arr[idx]}}, nil
+
+	case "slice":
+		arr, ok := m.Value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		start, end, step := sliceBounds(seg, len(arr))
+		var out []Match
+		if step > 0 {
+			for i := start; i < end; i += step {
+				out = append(out, Match{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: arr[i]})
+			}
+		} else if step < 0 {
+			for i := start; i > end; i += step {
+				out = append(out, Match{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: arr[i]})
+			}
+		}
+		return out, nil
+
+	case "filter":
+		switch v := m.Value.(type) {
+		case []interface{}:
+			var out []Match
+			for i, item := range v {
+				ok, err := evalPredicate(seg.filter, item)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					out = append(out, Match{Path: fmt.Sprintf("%s[%d]", m.Path, i), Value: item})
+				}
+			}
+			return out, nil
+		case map[string]interface{}:
+			var out []Match
+			for _, k := range sortedKeys(v) {
+				ok, err := evalPredicate(seg.filter, v[k])
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					out = append(out, Match{Path: m.Path + "." + k, Value: v[k]})
+				}
+			}
+			return out, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("jsonpath: unknown segment kind %q", seg.kind)
+}
+
+func sliceBounds(seg segment, length int) (start, end, step int) {
+	step = seg.step
+	if step == 0 {
+		step = 1
+	}
+	start, end = 0, length
+	if step < 0 {
+		start, end = length-1, -1
+	}
+	if seg.hasStart {
+		start = normalizeIndex(seg.start, length)
+	}
+	if seg.hasEnd {
+		end = normalizeIndex(seg.end, length)
+	}
+	return start, end, step
+}
+
+func normalizeIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > length {
+		return length
+	}
+	return i
+}
+
+var predicateRE = regexp.MustCompile(`^@\.([A-Za-z0-9_]+)\s*(==|!=|<=|>=|<|>|=~)\s*(.+)$`)
+
+// evalPredicate evaluates a single `@.field op value` filter predicate
+// against item, which must be a map for the field lookup to succeed.
+func evalPredicate(expr string, item interface{}) (bool, error) {
+	expr = strings.TrimSpace(expr)
+	groups := predicateRE.FindStringSubmatch(expr)
+	if groups == nil {
+		return false, fmt.Errorf("jsonpath: unsupported filter expression %q", expr)
+	}
+	field, op, rawValue := groups[1], groups[2], strings.TrimSpace(groups[3])
+
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	actual, present := obj[field]
+	if !present {
+		return false, nil
+	}
+
+	if op == "=~" {
+		pattern := strings.Trim(rawValue, "/")
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("jsonpath: bad regex %q: %w", pattern, err)
+		}
+		return re.MatchString(fmt.Sprintf("%v", actual)), nil
+	}
+
+	expected := parseLiteral(rawValue)
+	return compare(actual, expected, op)
+}
+
+func parseLiteral(raw string) interface{} {
+	if raw == "true" {
+		return true
+	}
+	if raw == "false" {
+		return false
+	}
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		return raw[1 : len(raw)-1]
+	}
+	if n, err := strconv.ParseFloat(raw, 64); err == nil {
+		return n
+	}
+	return raw
+}
+
+func compare(actual, expected interface{}, op string) (bool, error) {
+	af, aIsNum := toFloat(actual)
+	ef, eIsNum := toFloat(expected)
+
+	switch op {
+	case "==":
+		if aIsNum && eIsNum {
+			return af == ef, nil
+		}
+		return fmt.Sprintf("%v", actual) == fmt.Sprintf("%v", expected), nil
+	case "!=":
+		if aIsNum && eIsNum {
+			return af != ef, nil
+		}
+		return fmt.Sprintf("%v", actual) != fmt.Sprintf("%v", expected), nil
+	case "<", "<=", ">", ">=":
+		if !aIsNum || !eIsNum {
+			return false, fmt.Errorf("jsonpath: %s requires numeric operands", op)
+		}
+		switch op {
+		case "<":
+			return af < ef, nil
+		case "<=":
+			return af <= ef, nil
+		case ">":
+			return af > ef, nil
+		default:
+			return af >= ef, nil
+		}
+	}
+	return false, fmt.Errorf("jsonpath: unsupported operator %q", op)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+package jsonpath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func paths(matches []Match) []string {
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.Path
+	}
+	return out
+}
+
+func TestEval(t *testing.T) {
+	doc := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "age": 30.0},
+			map[string]interface{}{"name": "bob", "age": 25.0},
+			map[string]interface{}{"name": "carol", "age": 40.0},
+		},
+		"meta": map[string]interface{}{
+			"tags": []interface{}{"a", "b", "c"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		expr      string
+		wantPaths []string
+	}{
+		{
+			name:      "root",
+			expr:      "$",
+			wantPaths: []string{"$"},
+		},
+		{
+			name:      "key child",
+			expr:      "$.meta",
+			wantPaths: []string{"$.meta"},
+		},
+		{
+			name:      "bracket key child",
+			expr:      `$["meta"]`,
+			wantPaths: []string{"$.meta"},
+		},
+		{
+			name:      "array index",
+			expr:      "$.users[1].name",
+			wantPaths: []string{"$.users[1].name"},
+		},
+		{
+			name:      "negative array index",
+			expr:      "$.users[-1].name",
+			wantPaths: []string{"$.users[2].name"},
+		},
+		{
+			name:      "slice",
+			expr:      "$.users[0:2].name",
+			wantPaths: []string{"$.users[0].name", "$.users[1].name"},
+		},
+		{
+			name:      "slice with step",
+			expr:      "$.meta.tags[::2]",
+			wantPaths: []string{"$.meta.tags[0]", "$.meta.tags[2]"},
+		},
+		{
+			name:      "recursive descent",
+			expr:      "$..name",
+			wantPaths: []string{"$.users[0].name", "$.users[1].name", "$.users[2].name"},
+		},
+		{
+			name:      "wildcard",
+			expr:      "$.meta.tags[*]",
+			wantPaths: []string{"$.meta.tags[0]", "$.meta.tags[1]", "$.meta.tags[2]"},
+		},
+		{
+			name:      "filter predicate",
+			expr:      "$.users[?(@.age > 28)].name",
+			wantPaths: []string{"$.users[0].name", "$.users[2].name"},
+		},
+		{
+			name:      "filter predicate regex",
+			expr:      `$.users[?(@.name =~ /^b/)].name`,
+			wantPaths: []string{"$.users[1].name"},
+		},
+		{
+			name:      "no matches",
+			expr:      "$.nope",
+			wantPaths: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := Eval(doc, tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) returned error: %v", tt.expr, err)
+			}
+			got := paths(matches)
+			if len(got) == 0 && len(tt.wantPaths) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantPaths) {
+				t.Errorf("Eval(%q) paths = %v, want %v", tt.expr, got, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "missing root dollar", expr: "users[0]"},
+		{name: "unterminated bracket", expr: "$.users[0"},
+		{name: "bad index", expr: "$.users[x]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Eval(nil, tt.expr); err == nil {
+				t.Errorf("Eval(%q) expected an error, got nil", tt.expr)
+			}
+		})
+	}
+}
@@ -1,19 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dop251/goja"
+	"gopkg.in/yaml.v3"
+
+	"jv/internal/jsonpath"
 )
 
 // Mode represents the current view mode
@@ -35,22 +44,23 @@ const (
 
 // Styles using Lip Gloss
 var (
-	keyStyle          = lipgloss.NewStyle().Foreground(lipgloss.Color("#7AA2F7")).Bold(true)
-	stringStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A"))
-	numberStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF9E64"))
-	boolStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#BB9AF7"))
-	nullStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89")).Italic(true)
-	bracketStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#A9B1D6"))
-	collapsedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89"))
-	cursorStyle       = lipgloss.NewStyle().Background(lipgloss.Color("#3D59A1")).Foreground(lipgloss.Color("#C0CAF5"))
-	helpStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89")).Padding(0, 1)
-	titleStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#7AA2F7")).Bold(true).Padding(0, 1)
-	borderStyle       = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#3D59A1"))
-	errorStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#F7768E")).Bold(true)
-	hintStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A")).Italic(true)
-	statusStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A")).Bold(true).Padding(0, 1)
-	selectedKeyStyle  = lipgloss.NewStyle().Background(lipgloss.Color("#7AA2F7")).Foreground(lipgloss.Color("#1A1B26")).Bold(true)
-	selectedValStyle  = lipgloss.NewStyle().Background(lipgloss.Color("#9ECE6A")).Foreground(lipgloss.Color("#1A1B26"))
+	keyStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#7AA2F7")).Bold(true)
+	stringStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A"))
+	numberStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF9E64"))
+	boolStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#BB9AF7"))
+	nullStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89")).Italic(true)
+	bracketStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#A9B1D6"))
+	collapsedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89"))
+	cursorStyle      = lipgloss.NewStyle().Background(lipgloss.Color("#3D59A1")).Foreground(lipgloss.Color("#C0CAF5"))
+	helpStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#565F89")).Padding(0, 1)
+	titleStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#7AA2F7")).Bold(true).Padding(0, 1)
+	borderStyle      = lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#3D59A1"))
+	errorStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#F7768E")).Bold(true)
+	hintStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A")).Italic(true)
+	statusStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#9ECE6A")).Bold(true).Padding(0, 1)
+	selectedKeyStyle = lipgloss.NewStyle().Background(lipgloss.Color("#7AA2F7")).Foreground(lipgloss.Color("#1A1B26")).Bold(true)
+	selectedValStyle = lipgloss.NewStyle().Background(lipgloss.Color("#9ECE6A")).Foreground(lipgloss.Color("#1A1B26"))
+	matchStyle       = lipgloss.NewStyle().Background(lipgloss.Color("#E0AF68")).Foreground(lipgloss.Color("#1A1B26")).Bold(true)
 )
 
 // Node represents a JSON node in the tree
@@ -63,11 +73,34 @@ type Node struct {
 	Depth     int
 	Parent    *Node
 	Index     int // For array elements
+
+	// Lazy marks an object/array node produced by the streaming parser
+	// whose Children have not been materialized yet. RawStart/RawEnd are
+	// its byte range (inclusive of brackets) in Model.rawBytes.
+	Lazy     bool
+	RawStart int
+	RawEnd   int
+}
+
+// hasChildren reports whether node can be expanded, whether or not its
+// children have been materialized yet.
+func (node *Node) hasChildren() bool {
+	return len(node.Children) > 0 || node.Lazy
 }
 
 // clearStatusMsg is a message to clear the status
 type clearStatusMsg struct{}
 
+// searchMatch records a node that matched the active search query, along
+// with the character ranges (within its rendered key/value text) that the
+// fuzzy matcher attributes the score to, for inline highlighting.
+type searchMatch struct {
+	node        *Node
+	score       int
+	keyRanges   [][2]int
+	valueRanges [][2]int
+}
+
 // Model is the Bubble Tea model
 type Model struct {
 	mode          Mode
@@ -83,20 +116,56 @@ type Model struct {
 	jsonInput     string
 	parseError    error
 	statusMessage string
+
+	searching   bool
+	searchInput textinput.Model
+	matches     []searchMatch
+	matchIndex  int
+
+	querying     bool
+	queryInput   textinput.Model
+	queryError   error
+	originalRoot *Node // set while viewing a query result subtree, so it can be restored
+
+	format          string // detected/forced input format: json, yaml, toml, ndjson
+	outputFormatIdx int    // cycled by the "o" keybinding
+
+	streaming    bool   // true once the streaming/lazy parser built m.root
+	streamForced bool   // set by --stream regardless of input size
+	rawBytes     []byte // original input bytes, sliced for lazy node materialization and raw "y" copies
+
+	reducing    bool
+	reduceInput textinput.Model
+	jsHistory   []*Node // roots prior to each applied reduce, popped by "u"/ctrl+z
 }
 
+// streamThreshold is the input size above which parseJSON switches to the
+// streaming parser even without --stream, so multi-GB documents that would
+// otherwise OOM during eager json.Unmarshal stay browsable.
+const streamThreshold = 10 * 1024 * 1024 // 10 MB
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiffMode(os.Args[2:])
+		return
+	}
+
+	forcedFormat := flag.String("f", "", "force input format: json, yaml, toml, ndjson")
+	stream := flag.Bool("stream", false, "use the streaming parser with lazy child materialization")
+	flag.Parse()
+	args := flag.Args()
+
 	var m Model
 
 	// Check if there's input from file or stdin
-	if len(os.Args) > 1 {
+	if len(args) > 0 {
 		// Read from file
-		input, err := os.ReadFile(os.Args[1])
+		input, err := os.ReadFile(args[0])
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
 			os.Exit(1)
 		}
-		m = NewModelWithJSON(string(input))
+		m = NewModelWithJSON(string(input), *forcedFormat, *stream)
 	} else {
 		// Check if there's data on stdin
 		stat, _ := os.Stdin.Stat()
@@ -106,7 +175,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error reading stdin: %v\n", err)
 				os.Exit(1)
 			}
-			m = NewModelWithJSON(string(input))
+			m = NewModelWithJSON(string(input), *forcedFormat, *stream)
 		} else {
 			// No input - start in input mode
 			m = NewModelForInput()
@@ -140,11 +209,16 @@ Example:
 	}
 }
 
-// NewModelWithJSON creates a new model with the given JSON input
-func NewModelWithJSON(jsonInput string) Model {
+// NewModelWithJSON creates a new model with the given input, which may be
+// JSON, YAML, TOML, or NDJSON. forcedFormat overrides auto-detection when
+// non-empty (see parseInput). forceStream requests the streaming parser
+// regardless of input size (see parseJSON).
+func NewModelWithJSON(jsonInput string, forcedFormat string, forceStream bool) Model {
 	m := Model{
-		mode:      ViewMode,
-		jsonInput: jsonInput,
+		mode:         ViewMode,
+		jsonInput:    jsonInput,
+		format:       forcedFormat,
+		streamForced: forceStream,
 	}
 	m.parseJSON()
 	return m
@@ -152,16 +226,171 @@ func NewModelWithJSON(jsonInput string) Model {
 
 func (m *Model) parseJSON() {
 	m.parseError = nil
-	var data interface{}
-	if err := json.Unmarshal([]byte(m.jsonInput), &data); err != nil {
+
+	// The streaming/lazy path only understands plain JSON: honor a forced
+	// -f before falling back to auto-detection, so NDJSON/YAML/TOML never
+	// get routed into parseStreamed, which reads only the first JSON value.
+	detectedFormat := m.format
+	if detectedFormat == "" {
+		detectedFormat = detectFormat(m.jsonInput)
+	}
+
+	if (m.streamForced || len(m.jsonInput) > streamThreshold) && detectedFormat == "json" {
+		raw := []byte(m.jsonInput)
+		root, err := parseStreamed(raw)
+		if err != nil {
+			m.parseError = err
+			return
+		}
+		m.root = root
+		m.rawBytes = raw
+		m.streaming = true
+		m.format = "json"
+		m.flattenNodes()
+		return
+	}
+
+	m.streaming = false
+	data, format, err := parseInput(m.jsonInput, m.format)
+	if err != nil {
 		m.parseError = err
 		return
 	}
+	m.format = format
 
 	m.root = buildTree(data, "", 0, nil, -1)
 	m.flattenNodes()
 }
 
+// parseInput sniffs raw's format (unless forced is non-empty) and
+// normalizes it into the generic interface{} tree buildTree expects.
+func parseInput(raw string, forced string) (interface{}, string, error) {
+	format := forced
+	if format == "" {
+		format = detectFormat(raw)
+	}
+
+	switch format {
+	case "yaml":
+		var data interface{}
+		if err := yaml.Unmarshal([]byte(raw), &data); err != nil {
+			return nil, format, err
+		}
+		return normalizeJSValue(data), format, nil
+
+	case "toml":
+		var data map[string]interface{}
+		if _, err := toml.Decode(raw, &data); err != nil {
+			return nil, format, err
+		}
+		return normalizeJSValue(data), format, nil
+
+	case "ndjson":
+		var values []interface{}
+		for _, line := range strings.Split(raw, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			var v interface{}
+			if err := json.Unmarshal([]byte(line), &v); err != nil {
+				return nil, format, fmt.Errorf("ndjson: %w", err)
+			}
+			values = append(values, v)
+		}
+		return values, format, nil
+
+	default:
+		var data interface{}
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return nil, "json", err
+		}
+		return data, "json", nil
+	}
+}
+
+var (
+	tomlSectionRE = regexp.MustCompile(`(?m)^\s*\[[A-Za-z0-9_.]+\]\s*$`)
+	tomlAssignRE  = regexp.MustCompile(`(?m)^\s*[A-Za-z0-9_]+\s*=\s*\S.*$`)
+	yamlKeyRE     = regexp.MustCompile(`(?m)^\s*[A-Za-z0-9_-]+:\s*.*$`)
+)
+
+// detectFormat sniffs raw to guess its serialization format, falling back
+// to json if nothing else matches.
+func detectFormat(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "json"
+	}
+
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if looksLikeNDJSON(trimmed) {
+			return "ndjson"
+		}
+		return "json"
+	}
+	if strings.HasPrefix(trimmed, "---") {
+		return "yaml"
+	}
+	if tomlSectionRE.MatchString(trimmed) || tomlAssignRE.MatchString(trimmed) {
+		return "toml"
+	}
+	if yamlKeyRE.MatchString(trimmed) {
+		return "yaml"
+	}
+	return "json"
+}
+
+// looksLikeNDJSON reports whether trimmed is a sequence of multiple
+// newline-separated JSON values rather than a single JSON document.
+func looksLikeNDJSON(trimmed string) bool {
+	count := 0
+	for _, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "{") && !strings.HasPrefix(line, "[") {
+			return false
+		}
+		count++
+	}
+	return count > 1
+}
+
+var outputFormats = []string{"json", "yaml", "toml"}
+
+// serializeAs renders value in the given format for the "o" reserialize
+// keybinding.
+func serializeAs(value interface{}, format string) (string, error) {
+	switch format {
+	case "yaml":
+		b, err := yaml.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+
+	case "toml":
+		table, ok := value.(map[string]interface{})
+		if !ok {
+			table = map[string]interface{}{"value": value}
+		}
+		var sb strings.Builder
+		if err := toml.NewEncoder(&sb).Encode(table); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+
+	default:
+		b, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
 func buildTree(data interface{}, key string, depth int, parent *Node, index int) *Node {
 	node := &Node{
 		Key:    key,
@@ -202,6 +431,148 @@ func buildTree(data interface{}, key string, depth int, parent *Node, index int)
 	return node
 }
 
+// parseStreamed builds the root Node for raw using a token-driven
+// json.Decoder instead of json.Unmarshal: only the root's immediate
+// children are materialized; grandchildren are left as Lazy stubs
+// carrying their byte range in raw, parsed on demand by
+// materializeLazyNode when the user expands them. UseNumber preserves
+// integer precision that float64 would otherwise mangle.
+func parseStreamed(raw []byte) (*Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return decodeLazyValue(dec, 0, "", 0, nil, -1, true)
+}
+
+// materializeLazyNode parses node's byte range out of m.rawBytes to
+// populate one more level of Children, leaving any grandchildren Lazy in
+// turn. A no-op if node isn't a not-yet-loaded Lazy node.
+func (m *Model) materializeLazyNode(node *Node) {
+	if !node.Lazy || node.Children != nil {
+		return
+	}
+	slice := m.rawBytes[node.RawStart:node.RawEnd]
+	dec := json.NewDecoder(bytes.NewReader(slice))
+	dec.UseNumber()
+	built, err := decodeLazyValue(dec, node.RawStart, node.Key, node.Depth, node.Parent, node.Index, true)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Stream parse error: %v", err)
+		return
+	}
+	node.Children = built.Children
+	for _, child := range node.Children {
+		child.Parent = node
+	}
+	node.Lazy = false // fully loaded now, even if it turned out to have no children
+}
+
+// decodeLazyValue reads one JSON value from dec and builds its Node. base
+// is the offset of dec's underlying buffer within the original rawBytes,
+// so RawStart/RawEnd always refer to the original document even when dec
+// is reading a sliced-out sub-buffer (see materializeLazyNode). When
+// expand is false, object/array values are not recursed into: their
+// tokens are skipped and the node is left Lazy with its byte range
+// recorded instead.
+func decodeLazyValue(dec *json.Decoder, base int, key string, depth int, parent *Node, index int, expand bool) (*Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{Key: key, Depth: depth, Parent: parent, Index: index}
+
+	delim, isDelim := tok.(json.Delim)
+	if !isDelim {
+		switch v := tok.(type) {
+		case string:
+			node.Type = "string"
+			node.Value = v
+		case json.Number:
+			node.Type = "number"
+			node.Value = v
+		case bool:
+			node.Type = "bool"
+			node.Value = v
+		case nil:
+			node.Type = "null"
+		}
+		return node, nil
+	}
+
+	startOffset := base + int(dec.InputOffset()) - 1 // position of the delim itself
+
+	if delim == '{' {
+		node.Type = "object"
+	} else {
+		node.Type = "array"
+	}
+
+	if !expand {
+		if err := skipContainer(dec); err != nil {
+			return nil, err
+		}
+		node.Lazy = true
+		node.Collapsed = true
+		node.RawStart = startOffset
+		node.RawEnd = base + int(dec.InputOffset())
+		return node, nil
+	}
+
+	childIndex := 0
+	for dec.More() {
+		childKey := ""
+		childArrayIndex := -1
+		if delim == '{' {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			childKey, _ = keyTok.(string)
+		} else {
+			childArrayIndex = childIndex
+			childIndex++
+		}
+		child, err := decodeLazyValue(dec, base, childKey, depth+1, node, childArrayIndex, false)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	if _, err := dec.Token(); err != nil { // consume the closing delimiter
+		return nil, err
+	}
+	node.RawStart = startOffset
+	node.RawEnd = base + int(dec.InputOffset())
+
+	if delim == '{' {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].Key < node.Children[j].Key
+		})
+	}
+
+	return node, nil
+}
+
+// skipContainer advances dec past the remainder of the object/array whose
+// opening delimiter was just consumed, without materializing any values.
+func skipContainer(dec *json.Decoder) error {
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
 func (m *Model) flattenNodes() {
 	m.flatNodes = nil
 	if m.root != nil {
@@ -323,7 +694,11 @@ func (m *Model) getNodeValueAsString(node *Node) string {
 	case "null":
 		return "null"
 	case "object", "array":
-		// Serialize the whole subtree as JSON
+		if m.streaming {
+			// Lazy nodes have no decoded Value; slice the original bytes
+			// instead of re-marshaling so large untouched subtrees stay cheap.
+			return string(m.rawBytes[node.RawStart:node.RawEnd])
+		}
 		b, err := json.MarshalIndent(node.Value, "", "  ")
 		if err != nil {
 			return fmt.Sprintf("%v", node.Value)
@@ -336,6 +711,75 @@ func (m *Model) getNodeValueAsString(node *Node) string {
 func (m Model) updateViewMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m.searching {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "esc":
+				m.searching = false
+				m.searchInput.Blur()
+				m.matches = nil
+				m.matchIndex = 0
+				return m, nil
+			case "enter":
+				m.searching = false
+				m.searchInput.Blur()
+				if len(m.matches) > 0 {
+					m.jumpToMatch(0)
+				}
+				return m, nil
+			}
+		}
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		m.runSearch(m.searchInput.Value())
+		if m.ready {
+			m.viewport.SetContent(m.renderContent())
+		}
+		return m, cmd
+	}
+
+	if m.querying {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "esc":
+				m.querying = false
+				m.queryInput.Blur()
+				m.queryError = nil
+				return m, nil
+			case "enter":
+				m.querying = false
+				m.queryInput.Blur()
+				m.runQuery(m.queryInput.Value())
+				if m.ready {
+					m.viewport.SetContent(m.renderContent())
+				}
+				return m, clearStatusAfter(3 * time.Second)
+			}
+		}
+		m.queryInput, cmd = m.queryInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.reducing {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "esc":
+				m.reducing = false
+				m.reduceInput.Blur()
+				return m, nil
+			case "enter":
+				m.reducing = false
+				m.reduceInput.Blur()
+				m.runReduce(m.reduceInput.Value())
+				if m.ready {
+					m.viewport.SetContent(m.renderContent())
+				}
+				return m, clearStatusAfter(3 * time.Second)
+			}
+		}
+		m.reduceInput, cmd = m.reduceInput.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -363,7 +807,10 @@ func (m Model) updateViewMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Toggle collapse
 			if m.cursor < len(m.flatNodes) {
 				node := m.flatNodes[m.cursor]
-				if len(node.Children) > 0 {
+				if node.hasChildren() {
+					if node.Collapsed {
+						m.materializeLazyNode(node)
+					}
 					node.Collapsed = !node.Collapsed
 					m.flattenNodes()
 					// Ensure cursor doesn't go out of bounds
@@ -376,7 +823,7 @@ func (m Model) updateViewMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Collapse current or go to parent
 			if m.cursor < len(m.flatNodes) {
 				node := m.flatNodes[m.cursor]
-				if len(node.Children) > 0 && !node.Collapsed {
+				if node.hasChildren() && !node.Collapsed {
 					node.Collapsed = true
 					m.flattenNodes()
 				} else if node.Parent != nil {
@@ -450,6 +897,87 @@ func (m Model) updateViewMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Collapse all
 			m.collapseAll(m.root)
 			m.flattenNodes()
+		case "/":
+			// Enter search mode
+			ti := textinput.New()
+			ti.Prompt = "/"
+			ti.Placeholder = "search keys, values, paths..."
+			ti.Focus()
+			m.searchInput = ti
+			m.searching = true
+			m.matches = nil
+			m.matchIndex = 0
+			return m, textinput.Blink
+		case "n":
+			if len(m.matches) > 0 {
+				m.jumpToMatch((m.matchIndex + 1) % len(m.matches))
+			}
+		case "N":
+			if len(m.matches) > 0 {
+				m.jumpToMatch((m.matchIndex - 1 + len(m.matches)) % len(m.matches))
+			}
+		case ":":
+			// Enter JSONPath query mode
+			qi := textinput.New()
+			qi.Prompt = ":"
+			qi.Placeholder = "$.users[?(@.age > 30)].name"
+			qi.Focus()
+			m.queryInput = qi
+			m.querying = true
+			m.queryError = nil
+			return m, textinput.Blink
+		case "R":
+			// Restore the tree the query view replaced
+			if m.originalRoot != nil {
+				m.root = m.originalRoot
+				m.originalRoot = nil
+				m.flattenNodes()
+				m.cursor = 0
+			}
+		case "o":
+			// Re-serialize the current subtree, cycling output format each press
+			if m.cursor < len(m.flatNodes) {
+				node := m.flatNodes[m.cursor]
+				value := node.Value
+				if m.streaming && (node.Type == "object" || node.Type == "array") {
+					// Lazy nodes carry no decoded Value; rebuild one from the raw bytes.
+					if err := json.Unmarshal(m.rawBytes[node.RawStart:node.RawEnd], &value); err != nil {
+						m.statusMessage = fmt.Sprintf("Serialize error: %v", err)
+						return m, clearStatusAfter(2 * time.Second)
+					}
+				}
+				outFormat := outputFormats[m.outputFormatIdx%len(outputFormats)]
+				m.outputFormatIdx++
+				text, err := serializeAs(value, outFormat)
+				if err != nil {
+					m.statusMessage = fmt.Sprintf("Serialize error: %v", err)
+					return m, clearStatusAfter(2 * time.Second)
+				}
+				cmd = m.copyToClipboard(text, outFormat)
+				return m, cmd
+			}
+		case ".":
+			// Enter JS reduce mode
+			ri := textinput.New()
+			ri.Prompt = "."
+			ri.Placeholder = ".users.filter(u => u.age > 30).map(u => u.name)"
+			ri.Focus()
+			m.reduceInput = ri
+			m.reducing = true
+			return m, textinput.Blink
+		case "u", "ctrl+z":
+			// Undo the last applied reduce
+			if len(m.jsHistory) > 0 {
+				m.root = m.jsHistory[len(m.jsHistory)-1]
+				m.jsHistory = m.jsHistory[:len(m.jsHistory)-1]
+				m.flattenNodes()
+				m.cursor = 0
+				m.statusMessage = "Undid reduce"
+				if m.ready {
+					m.viewport.SetContent(m.renderContent())
+				}
+				return m, clearStatusAfter(2 * time.Second)
+			}
 		case "g":
 			// Go to top
 			m.cursor = 0
@@ -502,6 +1030,390 @@ func (m *Model) getJSONPath(node *Node) string {
 	return path
 }
 
+// runSearch re-scores every node in the tree against query, auto-expands
+// the ancestors of any match so it is reachable in flatNodes, and resets
+// the match cursor to the best-scoring match.
+func (m *Model) runSearch(query string) {
+	m.matches = nil
+	m.matchIndex = 0
+	if strings.TrimSpace(query) == "" || m.root == nil {
+		m.flattenNodes()
+		return
+	}
+
+	var walk func(node *Node)
+	walk = func(node *Node) {
+		var best searchMatch
+		found := false
+
+		if node.Key != "" {
+			if ok, score, ranges := fuzzyMatch(query, node.Key); ok {
+				best = searchMatch{node: node, score: score, keyRanges: ranges}
+				found = true
+			}
+		}
+		if node.Type != "object" && node.Type != "array" {
+			valueText := m.getNodeValueAsString(node)
+			if ok, score, ranges := fuzzyMatch(query, valueText); ok && (!found || score > best.score) {
+				best = searchMatch{node: node, score: score, valueRanges: ranges}
+				found = true
+			}
+		}
+		path := m.getJSONPath(node)
+		// Path matches count toward m.matches (and n/N navigation) same as
+		// key/value matches, but paths aren't rendered inline anywhere, so
+		// there's no ranges to keep for highlighting.
+		if ok, score, _ := fuzzyMatch(query, path); ok && (!found || score > best.score) {
+			best = searchMatch{node: node, score: score}
+			found = true
+		}
+
+		if found {
+			m.matches = append(m.matches, best)
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(m.root)
+
+	sort.SliceStable(m.matches, func(i, j int) bool {
+		return m.matches[i].score > m.matches[j].score
+	})
+
+	for _, match := range m.matches {
+		for ancestor := match.node.Parent; ancestor != nil; ancestor = ancestor.Parent {
+			ancestor.Collapsed = false
+		}
+	}
+	m.flattenNodes()
+}
+
+// jumpToMatch moves the cursor to the i-th entry of m.matches.
+func (m *Model) jumpToMatch(i int) {
+	if i < 0 || i >= len(m.matches) {
+		return
+	}
+	m.matchIndex = i
+	target := m.matches[i].node
+	for j, n := range m.flatNodes {
+		if n == target {
+			m.cursor = j
+			m.ensureCursorVisible()
+			break
+		}
+	}
+}
+
+// matchForNode returns the search match recorded for node, if any.
+func (m *Model) matchForNode(node *Node) (searchMatch, bool) {
+	for _, match := range m.matches {
+		if match.node == node {
+			return match, true
+		}
+	}
+	return searchMatch{}, false
+}
+
+// fuzzyMatch performs a case-insensitive subsequence match of pattern
+// against text, returning whether it matched, a score that rewards
+// consecutive runs, word-boundary starts, and case-exact hits, and the
+// matched character ranges (in text's original casing) for highlighting.
+func fuzzyMatch(pattern, text string) (bool, int, [][2]int) {
+	if pattern == "" {
+		return false, 0, nil
+	}
+	lowerPattern := strings.ToLower(pattern)
+	lowerText := strings.ToLower(text)
+
+	pi := 0
+	score := 0
+	consecutive := 0
+	var ranges [][2]int
+	var runStart = -1
+
+	ti := 0
+	for ; ti < len(lowerText) && pi < len(lowerPattern); ti++ {
+		if lowerText[ti] != lowerPattern[pi] {
+			if runStart != -1 {
+				ranges = append(ranges, [2]int{runStart, ti})
+				runStart = -1
+			}
+			consecutive = 0
+			continue
+		}
+
+		if runStart == -1 {
+			runStart = ti
+		}
+		consecutive++
+		score += 1 + consecutive*2
+
+		if ti == 0 || lowerText[ti-1] == '_' || lowerText[ti-1] == '-' || lowerText[ti-1] == '.' || lowerText[ti-1] == '/' || lowerText[ti-1] == ' ' {
+			score += 5
+		}
+		if text[ti] == pattern[pi] {
+			score += 1
+		}
+		pi++
+	}
+	if runStart != -1 {
+		ranges = append(ranges, [2]int{runStart, ti})
+	}
+
+	if pi < len(lowerPattern) {
+		return false, 0, nil
+	}
+	return true, score, ranges
+}
+
+// highlightRanges wraps the runs described by ranges in matchStyle and
+// renders the remainder of text with base.
+func highlightRanges(text string, ranges [][2]int, base lipgloss.Style) string {
+	if len(ranges) == 0 {
+		return base.Render(text)
+	}
+	var sb strings.Builder
+	pos := 0
+	for _, r := range ranges {
+		if r[0] > pos {
+			sb.WriteString(base.Render(text[pos:r[0]]))
+		}
+		sb.WriteString(matchStyle.Render(text[r[0]:r[1]]))
+		pos = r[1]
+	}
+	if pos < len(text) {
+		sb.WriteString(base.Render(text[pos:]))
+	}
+	return sb.String()
+}
+
+// runQuery evaluates a JSONPath expression against the original tree. A
+// single match jumps the cursor to that node (expanding its ancestors);
+// zero matches restore the original tree and report "No matches";
+// multiple matches swap the view to a synthetic subtree listing every
+// result, keyed by the path that produced it, restorable with "R".
+func (m *Model) runQuery(expr string) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return
+	}
+	if m.streaming {
+		// Lazy nodes have no decoded Value for the evaluator to walk.
+		m.statusMessage = "JSONPath queries are unavailable in streaming mode"
+		return
+	}
+
+	base := m.originalRoot
+	if base == nil {
+		base = m.root
+	}
+
+	matches, err := jsonpath.Eval(base.Value, expr)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Query error: %v", err)
+		return
+	}
+	if len(matches) == 0 {
+		m.root = base
+		m.originalRoot = nil
+		m.flattenNodes()
+		m.statusMessage = "No matches"
+		return
+	}
+
+	if len(matches) == 1 {
+		m.root = base
+		m.originalRoot = nil
+		target := m.findNodeByPath(m.root, matches[0].Path)
+		if target != nil {
+			for ancestor := target.Parent; ancestor != nil; ancestor = ancestor.Parent {
+				ancestor.Collapsed = false
+			}
+			m.flattenNodes()
+			for i, n := range m.flatNodes {
+				if n == target {
+					m.cursor = i
+					m.ensureCursorVisible()
+					break
+				}
+			}
+			m.statusMessage = fmt.Sprintf("Jumped to %s", matches[0].Path)
+			return
+		}
+		m.flattenNodes()
+		return
+	}
+
+	m.originalRoot = base
+	m.root = buildQueryResultRoot(matches)
+	m.flattenNodes()
+	m.cursor = 0
+	m.statusMessage = fmt.Sprintf("%d matches — press R to restore", len(matches))
+}
+
+// jsPrelude defines the small stdlib exposed to reduce-mode expressions.
+const jsPrelude = `
+function keys(o) { return Object.keys(o); }
+function values(o) { return Object.values(o); }
+function entries(o) { return Object.entries(o); }
+function len(x) {
+	if (x === null || x === undefined) return 0;
+	if (Array.isArray(x)) return x.length;
+	if (typeof x === "string") return x.length;
+	if (typeof x === "object") return Object.keys(x).length;
+	return 0;
+}
+function uniq(arr) {
+	var seen = {}, out = [];
+	arr.forEach(function(v) {
+		var k = JSON.stringify(v);
+		if (!seen[k]) { seen[k] = true; out.push(v); }
+	});
+	return out;
+}
+function sum(arr) { return arr.reduce(function(a, b) { return a + b; }, 0); }
+function groupBy(arr, fn) {
+	var out = {};
+	arr.forEach(function(v) {
+		var k = fn(v);
+		(out[k] = out[k] || []).push(v);
+	});
+	return out;
+}
+function sortBy(arr, fn) {
+	var copy = arr.slice();
+	copy.sort(function(a, b) {
+		var av = fn(a), bv = fn(b);
+		if (av < bv) return -1;
+		if (av > bv) return 1;
+		return 0;
+	});
+	return copy;
+}
+`
+
+// reduceTimeout bounds how long a single reduce-mode expression may run
+// before it's interrupted, so a typo like `while(true){}` can't hang the
+// Bubble Tea event loop forever.
+const reduceTimeout = 3 * time.Second
+
+// evalReduceExpr evaluates a reduce-mode expression against root using an
+// embedded goja JS runtime. Expressions starting with "." (e.g.
+// ".users.filter(u => u.age > 30)") are shorthand for chaining off the
+// root value, matching the fx convention.
+func evalReduceExpr(root interface{}, expr string) (interface{}, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	script := expr
+	if strings.HasPrefix(expr, ".") {
+		script = "root" + expr
+	}
+
+	vm := goja.New()
+	timer := time.AfterFunc(reduceTimeout, func() {
+		vm.Interrupt(fmt.Errorf("expression took longer than %s", reduceTimeout))
+	})
+	defer timer.Stop()
+
+	if _, err := vm.RunString(jsPrelude); err != nil {
+		return nil, err
+	}
+	if err := vm.Set("root", root); err != nil {
+		return nil, err
+	}
+	v, err := vm.RunString(script)
+	if err != nil {
+		return nil, err
+	}
+	return v.Export(), nil
+}
+
+// runReduce evaluates expr against the current tree and, on success,
+// pushes the current root onto m.jsHistory and swaps in a tree built from
+// the result, so "u"/ctrl+z can chain back through prior reductions.
+func (m *Model) runReduce(expr string) {
+	if strings.TrimSpace(expr) == "" {
+		return
+	}
+	if m.streaming {
+		// Lazy nodes have no decoded Value for the runtime to operate on.
+		m.statusMessage = "JS reduce is unavailable in streaming mode"
+		return
+	}
+
+	result, err := evalReduceExpr(m.root.Value, expr)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Reduce error: %v", err)
+		return
+	}
+
+	m.jsHistory = append(m.jsHistory, m.root)
+	m.root = buildTree(normalizeJSValue(result), "", 0, nil, -1)
+	m.flattenNodes()
+	m.cursor = 0
+	m.statusMessage = "Reduced — press u to undo"
+}
+
+// normalizeJSValue recursively converts non-JSON scalar types produced by
+// other decoders (goja's int64 for whole numbers, go-yaml's int, TOML's
+// int64/uint64) into the float64/map/slice shapes buildTree expects.
+func normalizeJSValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case int:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case uint64:
+		return float64(val)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, vv := range val {
+			out[k] = normalizeJSValue(vv)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, vv := range val {
+			out[i] = normalizeJSValue(vv)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// findNodeByPath walks node's subtree for the descendant whose JSONPath
+// (per getJSONPath) equals path.
+func (m *Model) findNodeByPath(node *Node, path string) *Node {
+	if node == nil {
+		return nil
+	}
+	if m.getJSONPath(node) == path {
+		return node
+	}
+	for _, child := range node.Children {
+		if found := m.findNodeByPath(child, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// buildQueryResultRoot wraps a JSONPath evaluation result in a synthetic
+// array Node so it can be browsed like any other subtree; each entry's
+// key is the path that produced it.
+func buildQueryResultRoot(matches []jsonpath.Match) *Node {
+	root := &Node{Type: "array", Depth: 0, Index: -1}
+	for _, match := range matches {
+		child := buildTree(match.Value, match.Path, 1, root, -1)
+		root.Children = append(root.Children, child)
+	}
+	return root
+}
+
 func (m *Model) ensureCursorVisible() {
 	if m.cursor < m.viewport.YOffset {
 		m.viewport.YOffset = m.cursor
@@ -510,6 +1422,9 @@ func (m *Model) ensureCursorVisible() {
 	}
 }
 
+// expandAll recursively uncollapses node's already-materialized subtree.
+// In streaming mode it deliberately does not materialize Lazy children —
+// doing so would defeat the point of lazy loading on huge documents.
 func (m *Model) expandAll(node *Node) {
 	if node == nil {
 		return
@@ -524,7 +1439,7 @@ func (m *Model) collapseAll(node *Node) {
 	if node == nil {
 		return
 	}
-	if len(node.Children) > 0 {
+	if node.hasChildren() {
 		node.Collapsed = true
 	}
 	for _, child := range node.Children {
@@ -582,12 +1497,18 @@ func (m Model) viewViewMode() string {
 
 	// Header
 	title := titleStyle.Render("JSON Viewer")
-	info := helpStyle.Render(fmt.Sprintf("%d nodes", len(m.flatNodes)))
+	infoText := fmt.Sprintf("%d nodes • %s", len(m.flatNodes), strings.ToUpper(m.format))
+	if m.streaming {
+		infoText += " • STREAMING"
+	}
+	info := helpStyle.Render(infoText)
 
 	// Status message or info
 	var headerRight string
 	if m.statusMessage != "" {
 		headerRight = statusStyle.Render(m.statusMessage)
+	} else if len(m.matches) > 0 {
+		headerRight = statusStyle.Render(fmt.Sprintf("%d/%d matches", m.matchIndex+1, len(m.matches)))
 	} else {
 		headerRight = info
 	}
@@ -595,12 +1516,34 @@ func (m Model) viewViewMode() string {
 	header := lipgloss.JoinHorizontal(lipgloss.Top, title, strings.Repeat(" ", max(0, m.width-lipgloss.Width(title)-lipgloss.Width(headerRight)-4)), headerRight)
 
 	// Footer with help
-	help := helpStyle.Render("↑↓/jk: nav • ←→: collapse • tab: select • y: copy value • Y: copy key • p: copy path • i: edit • q: quit")
+	help := helpStyle.Render("↑↓/jk: nav • ←→: collapse • tab: select • y: copy value • Y: copy key • p: copy path • o: copy as... • /: search • :: query • .: reduce • i: edit • q: quit")
+	if m.searching {
+		help = helpStyle.Render("enter: confirm • esc: cancel • type to fuzzy-match keys/values/paths")
+	} else if m.querying {
+		help = helpStyle.Render("enter: run query • esc: cancel • JSONPath syntax, e.g. $..name")
+	} else if m.reducing {
+		help = helpStyle.Render("enter: run expression • esc: cancel • JS, e.g. .users.map(u => u.name)")
+	} else if m.originalRoot != nil {
+		help = helpStyle.Render("↑↓/jk: nav • R: restore original tree • q: quit")
+	} else if len(m.jsHistory) > 0 {
+		help = helpStyle.Render("↑↓/jk: nav • u/ctrl+z: undo reduce • .: reduce again • q: quit")
+	}
 
 	// Main content with border
 	content := borderStyle.Width(m.width - 2).Render(m.viewport.View())
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, content, help)
+	var parts []string
+	parts = append(parts, header, content)
+	if m.searching {
+		parts = append(parts, borderStyle.Width(m.width-2).Render(m.searchInput.View()))
+	} else if m.querying {
+		parts = append(parts, borderStyle.Width(m.width-2).Render(m.queryInput.View()))
+	} else if m.reducing {
+		parts = append(parts, borderStyle.Width(m.width-2).Render(m.reduceInput.View()))
+	}
+	parts = append(parts, help)
+
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
 func (m Model) renderContent() string {
@@ -627,7 +1570,7 @@ func (m Model) renderNode(node *Node, isCursor bool) string {
 	line.WriteString(indent)
 
 	// Add collapse/expand indicator for containers
-	if len(node.Children) > 0 {
+	if node.hasChildren() {
 		if node.Collapsed {
 			line.WriteString(collapsedStyle.Render("▸ "))
 		} else {
@@ -642,6 +1585,13 @@ func (m Model) renderNode(node *Node, isCursor bool) string {
 		keyText := fmt.Sprintf(`"%s"`, node.Key)
 		if isCursor && m.selection == SelectKey {
 			line.WriteString(selectedKeyStyle.Render(keyText))
+		} else if match, ok := m.matchForNode(node); ok && len(match.keyRanges) > 0 {
+			// Ranges were computed against the bare key; shift by 1 for the opening quote.
+			shifted := make([][2]int, len(match.keyRanges))
+			for i, r := range match.keyRanges {
+				shifted[i] = [2]int{r[0] + 1, r[1] + 1}
+			}
+			line.WriteString(highlightRanges(keyText, shifted, keyStyle))
 		} else {
 			line.WriteString(keyStyle.Render(keyText))
 		}
@@ -669,6 +1619,35 @@ func (m Model) renderNode(node *Node, isCursor bool) string {
 	return lineStr
 }
 
+// renderContainer renders the collapsed/expanded/empty states shared by
+// object and array values. A Lazy node whose Children haven't been
+// materialized yet renders as "not-yet-loaded" rather than claiming to be
+// empty, since its item count isn't known without parsing it.
+func renderContainer(node *Node, open, close string, style lipgloss.Style, highlight bool) string {
+	notLoaded := node.Lazy && node.Children == nil
+
+	if node.Collapsed || notLoaded {
+		inner := "..."
+		if !notLoaded {
+			inner = fmt.Sprintf("...%d items", len(node.Children))
+		}
+		if highlight {
+			return style.Render(open + inner + close)
+		}
+		return bracketStyle.Render(open) + collapsedStyle.Render(inner) + bracketStyle.Render(close)
+	}
+	if len(node.Children) == 0 {
+		if highlight {
+			return style.Render(open + close)
+		}
+		return bracketStyle.Render(open + close)
+	}
+	if highlight {
+		return style.Render(open)
+	}
+	return bracketStyle.Render(open)
+}
+
 func (m Model) renderValue(node *Node, highlight bool) string {
 	style := selectedValStyle
 	if !highlight {
@@ -677,52 +1656,30 @@ func (m Model) renderValue(node *Node, highlight bool) string {
 
 	switch node.Type {
 	case "object":
-		if node.Collapsed {
-			text := fmt.Sprintf("{...%d items}", len(node.Children))
-			if highlight {
-				return style.Render(text)
-			}
-			return bracketStyle.Render("{") + collapsedStyle.Render(fmt.Sprintf("...%d items", len(node.Children))) + bracketStyle.Render("}")
-		} else if len(node.Children) == 0 {
-			if highlight {
-				return style.Render("{}")
-			}
-			return bracketStyle.Render("{}")
-		} else {
-			if highlight {
-				return style.Render("{")
-			}
-			return bracketStyle.Render("{")
-		}
+		return renderContainer(node, "{", "}", style, highlight)
 	case "array":
-		if node.Collapsed {
-			text := fmt.Sprintf("[...%d items]", len(node.Children))
-			if highlight {
-				return style.Render(text)
-			}
-			return bracketStyle.Render("[") + collapsedStyle.Render(fmt.Sprintf("...%d items", len(node.Children))) + bracketStyle.Render("]")
-		} else if len(node.Children) == 0 {
-			if highlight {
-				return style.Render("[]")
-			}
-			return bracketStyle.Render("[]")
-		} else {
-			if highlight {
-				return style.Render("[")
-			}
-			return bracketStyle.Render("[")
-		}
+		return renderContainer(node, "[", "]", style, highlight)
 	case "string":
 		text := fmt.Sprintf(`"%s"`, node.Value.(string))
 		if highlight {
 			return style.Render(text)
 		}
+		if match, ok := m.matchForNode(node); ok && len(match.valueRanges) > 0 {
+			shifted := make([][2]int, len(match.valueRanges))
+			for i, r := range match.valueRanges {
+				shifted[i] = [2]int{r[0] + 1, r[1] + 1}
+			}
+			return highlightRanges(text, shifted, stringStyle)
+		}
 		return stringStyle.Render(text)
 	case "number":
 		text := fmt.Sprintf("%v", node.Value)
 		if highlight {
 			return style.Render(text)
 		}
+		if match, ok := m.matchForNode(node); ok && len(match.valueRanges) > 0 {
+			return highlightRanges(text, match.valueRanges, numberStyle)
+		}
 		return numberStyle.Render(text)
 	case "bool":
 		text := fmt.Sprintf("%v", node.Value)